@@ -0,0 +1,279 @@
+package mysqltest
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// UpdateGoldenEnv is the environment variable that makes AssertQueryGolden (re)write golden files
+// instead of comparing against them. Its value is parsed with strconv.ParseBool.
+const UpdateGoldenEnv = "MYSQLTEST_UPDATE"
+
+// binaryTypes lists the database type names whose values are formatted as hex in golden tables.
+var binaryTypes = map[string]bool{
+	"BINARY":     true,
+	"VARBINARY":  true,
+	"TINYBLOB":   true,
+	"BLOB":       true,
+	"MEDIUMBLOB": true,
+	"LONGBLOB":   true,
+	"BIT":        true,
+	"GEOMETRY":   true,
+}
+
+// numericTypes lists the database type names, without the UNSIGNED prefix,
+// whose values are sorted numerically in golden tables.
+var numericTypes = map[string]bool{
+	"TINYINT":   true,
+	"SMALLINT":  true,
+	"MEDIUMINT": true,
+	"INT":       true,
+	"BIGINT":    true,
+	"DECIMAL":   true,
+	"FLOAT":     true,
+	"DOUBLE":    true,
+	"YEAR":      true,
+}
+
+// AssertQueryGolden runs the query and compares its result set with the golden file at goldenPath.
+// The result set is formatted as a text table whose rows are sorted, so the comparison does not
+// depend on the order in which MySQL returns rows. Rows are ordered column by column:
+// NULL comes first, numeric columns are compared by value and other columns by their formatted text.
+//
+// NULL values are written as NULL and binary column values as 0x-prefixed hex.
+// FLOAT and DOUBLE values are written in their shortest form at the column's precision,
+// so the table is the same whether or not args are given.
+// Other values are written as is, unless they would be ambiguous in the table, in which case they are quoted.
+//
+// When the UpdateGoldenEnv environment variable is set to a true value, e.g. MYSQLTEST_UPDATE=1,
+// the golden file is (re)written with the actual result instead.
+func (c *Conn) AssertQueryGolden(t *testing.T, query string, goldenPath string, args ...any) {
+	t.Helper()
+
+	rows, err := c.DB.Query(query, args...)
+	if err != nil {
+		t.Fatalf("mysqltest: %v", err)
+	}
+	defer rows.Close()
+
+	actual, err := formatRows(rows)
+	if err != nil {
+		t.Fatalf("mysqltest: %v", err)
+	}
+
+	update, _ := strconv.ParseBool(os.Getenv(UpdateGoldenEnv))
+	if err := compareGolden(actual, goldenPath, update); err != nil {
+		t.Errorf("mysqltest: %v", err)
+	}
+}
+
+func compareGolden(actual []byte, goldenPath string, update bool) error {
+	if update {
+		if err := os.MkdirAll(filepath.Dir(goldenPath), 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(goldenPath, actual, 0644)
+	}
+
+	expected, err := os.ReadFile(goldenPath)
+	if err != nil {
+		return fmt.Errorf("%w (set %s=1 to create the golden file)", err, UpdateGoldenEnv)
+	}
+	if !bytes.Equal(actual, expected) {
+		return fmt.Errorf("result of query does not match golden file %s\n--- got:\n%s--- want:\n%s",
+			goldenPath, actual, expected)
+	}
+	return nil
+}
+
+type column struct {
+	name     string
+	typeName string
+}
+
+type cell struct {
+	text string
+	null bool
+	num  *big.Rat
+}
+
+func formatRows(rows *sql.Rows) ([]byte, error) {
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]column, len(columnTypes))
+	for i, columnType := range columnTypes {
+		columns[i] = column{name: columnType.Name(), typeName: columnType.DatabaseTypeName()}
+	}
+
+	var table [][]any
+	for rows.Next() {
+		values := make([]any, len(columns))
+		dest := make([]any, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		table = append(table, values)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return formatTable(columns, table), nil
+}
+
+func formatTable(columns []column, values [][]any) []byte {
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.name
+	}
+
+	table := make([][]cell, len(values))
+	for i, row := range values {
+		table[i] = make([]cell, len(columns))
+		for j, value := range row {
+			table[i][j] = newCell(value, columns[j].typeName)
+		}
+	}
+	slices.SortFunc(table, func(a, b []cell) int {
+		return slices.CompareFunc(a, b, compareCells)
+	})
+
+	widths := make([]int, len(columns))
+	for i, name := range header {
+		widths[i] = utf8.RuneCountInString(name)
+	}
+	for _, row := range table {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell.text))
+		}
+	}
+
+	var buf bytes.Buffer
+	writeRow := func(row []string) {
+		for i, text := range row {
+			if i > 0 {
+				buf.WriteString(" | ")
+			}
+			buf.WriteString(text)
+			if i < len(row)-1 {
+				buf.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(text)))
+			}
+		}
+		buf.WriteByte('\n')
+	}
+	writeRow(header)
+	separator := make([]string, len(columns))
+	for i, width := range widths {
+		separator[i] = strings.Repeat("-", width)
+	}
+	writeRow(separator)
+	for _, row := range table {
+		texts := make([]string, len(row))
+		for i, cell := range row {
+			texts[i] = cell.text
+		}
+		writeRow(texts)
+	}
+	return buf.Bytes()
+}
+
+func newCell(value any, typeName string) cell {
+	if value == nil {
+		return cell{text: "NULL", null: true}
+	}
+	c := cell{text: formatValue(value, typeName)}
+	if numericTypes[strings.TrimPrefix(typeName, "UNSIGNED ")] {
+		if num, ok := new(big.Rat).SetString(c.text); ok {
+			c.num = num
+		}
+	}
+	return c
+}
+
+func compareCells(a, b cell) int {
+	switch {
+	case a.null && b.null:
+		return 0
+	case a.null:
+		return -1
+	case b.null:
+		return 1
+	case a.num != nil && b.num != nil:
+		if c := a.num.Cmp(b.num); c != 0 {
+			return c
+		}
+	}
+	return strings.Compare(a.text, b.text)
+}
+
+func formatValue(value any, typeName string) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case []byte:
+		if binaryTypes[typeName] {
+			return "0x" + strings.ToUpper(hex.EncodeToString(v))
+		}
+		if typeName == "FLOAT" || typeName == "DOUBLE" {
+			if f, err := strconv.ParseFloat(string(v), 64); err == nil {
+				return formatFloat(f, typeName)
+			}
+		}
+		return quoteIfNeeded(string(v))
+	case string:
+		return formatValue([]byte(v), typeName)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		return formatFloat(v, typeName)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return quoteIfNeeded(fmt.Sprint(v))
+	}
+}
+
+// formatFloat formats f in its shortest form at the precision of the column,
+// so a FLOAT column reads the same whether the driver returns it as text, float32 or float64.
+func formatFloat(f float64, typeName string) string {
+	bitSize := 64
+	if typeName == "FLOAT" {
+		bitSize = 32
+	}
+	return strconv.FormatFloat(f, 'g', -1, bitSize)
+}
+
+func quoteIfNeeded(s string) string {
+	if needsQuote(s) {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// needsQuote reports whether s cannot be written verbatim without being confused
+// with NULL, a column separator, or surrounding padding.
+func needsQuote(s string) bool {
+	if s == "" || s == "NULL" || s[0] == '"' || !utf8.ValidString(s) || strings.Contains(s, "|") {
+		return true
+	}
+	if s != strings.TrimSpace(s) {
+		return true
+	}
+	return strings.IndexFunc(s, func(r rune) bool { return !unicode.IsPrint(r) }) >= 0
+}
@@ -0,0 +1,104 @@
+package mysqltest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFormatValue(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    any
+		typeName string
+		expected string
+	}{
+		{name: "SQL NULL", value: nil, typeName: "VARCHAR", expected: `NULL`},
+		{name: "string NULL", value: []byte("NULL"), typeName: "VARCHAR", expected: `"NULL"`},
+		{name: "empty string", value: []byte(""), typeName: "VARCHAR", expected: `""`},
+		{name: "plain string", value: []byte("héllo world"), typeName: "VARCHAR", expected: `héllo world`},
+		{name: "separator", value: []byte("a|b"), typeName: "VARCHAR", expected: `"a|b"`},
+		{name: "leading space", value: []byte(" a"), typeName: "VARCHAR", expected: `" a"`},
+		{name: "trailing space", value: []byte("a "), typeName: "VARCHAR", expected: `"a "`},
+		{name: "leading quote", value: []byte(`"a`), typeName: "VARCHAR", expected: `"\"a"`},
+		{name: "non-printable", value: []byte("a\tb\n"), typeName: "VARCHAR", expected: `"a\tb\n"`},
+		{name: "invalid UTF-8", value: []byte{'a', 0xff}, typeName: "VARCHAR", expected: `"a\xff"`},
+		{name: "blob", value: []byte{0xca, 0xfe}, typeName: "BLOB", expected: `0xCAFE`},
+		{name: "empty blob", value: []byte{}, typeName: "BLOB", expected: `0x`},
+		{name: "bit", value: []byte{0x05}, typeName: "BIT", expected: `0x05`},
+		{name: "varbinary", value: []byte("NULL"), typeName: "VARBINARY", expected: `0x4E554C4C`},
+		{name: "int", value: int64(-10), typeName: "INT", expected: `-10`},
+		{name: "unsigned bigint", value: uint64(18446744073709551615), typeName: "UNSIGNED BIGINT", expected: `18446744073709551615`},
+		{name: "decimal", value: []byte("1.50"), typeName: "DECIMAL", expected: `1.50`},
+		{name: "float as text", value: []byte("0.1"), typeName: "FLOAT", expected: `0.1`},
+		{name: "float as float32", value: float32(0.1), typeName: "FLOAT", expected: `0.1`},
+		{name: "float as float64", value: float64(float32(0.1)), typeName: "FLOAT", expected: `0.1`},
+		{name: "double as text", value: []byte("1e20"), typeName: "DOUBLE", expected: `1e+20`},
+		{name: "double as float64", value: 1e20, typeName: "DOUBLE", expected: `1e+20`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := formatValue(tc.value, tc.typeName)
+			if actual != tc.expected {
+				t.Errorf("unexpected value: got %s, want %s", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestFormatTable(t *testing.T) {
+	columns := []column{
+		{name: "id", typeName: "INT"},
+		{name: "name", typeName: "VARCHAR"},
+		{name: "data", typeName: "BLOB"},
+		{name: "flags", typeName: "BIT"},
+	}
+	values := [][]any{
+		{int64(10), []byte("cherry"), []byte{0xff}, []byte{0x01}},
+		{int64(2), []byte("NULL"), nil, []byte{0x00}},
+		{nil, []byte(""), []byte{}, nil},
+		{int64(1), nil, []byte{0xca, 0xfe}, []byte{0x05}},
+	}
+	expected := `id   | name   | data   | flags
+---- | ------ | ------ | -----
+NULL | ""     | 0x     | NULL
+1    | NULL   | 0xCAFE | 0x05
+2    | "NULL" | NULL   | 0x00
+10   | cherry | 0xFF   | 0x01
+`
+
+	actual := string(formatTable(columns, values))
+	if actual != expected {
+		t.Errorf("unexpected table:\n--- got:\n%s--- want:\n%s", actual, expected)
+	}
+}
+
+func TestCompareGolden(t *testing.T) {
+	dir := t.TempDir()
+	goldenPath := filepath.Join(dir, "query.golden")
+	if err := os.WriteFile(goldenPath, []byte("id\n--\n1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := compareGolden([]byte("id\n--\n1\n"), goldenPath, false); err != nil {
+		t.Errorf("expected match, got %v", err)
+	}
+	if err := compareGolden([]byte("id\n--\n2\n"), goldenPath, false); err == nil {
+		t.Error("expected mismatch to be reported")
+	}
+	if err := compareGolden([]byte("id\n--\n1\n"), filepath.Join(dir, "missing.golden"), false); err == nil {
+		t.Error("expected missing golden file to be reported")
+	}
+
+	updatedPath := filepath.Join(dir, "sub", "updated.golden")
+	if err := compareGolden([]byte("id\n--\n3\n"), updatedPath, true); err != nil {
+		t.Fatal(err)
+	}
+	updated, err := os.ReadFile(updatedPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(updated) != "id\n--\n3\n" {
+		t.Errorf("unexpected golden file content: %q", updated)
+	}
+}
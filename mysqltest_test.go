@@ -92,6 +92,38 @@ func TestAddTodo(t *testing.T) {
 	}
 }
 
+func TestAssertQueryGolden(t *testing.T) {
+	// Setup
+	rootUser := "root"
+	rootPassword := getEnvOr("MYSQL_ROOT_PASSWORD", "root")
+	mysqlPort := getEnvOr("MYSQL_PORT", "3306")
+	query1 := "CREATE TABLE items (" +
+		"id INT PRIMARY KEY, " +
+		"name VARCHAR(255), " +
+		"data VARBINARY(16), " +
+		"score FLOAT)"
+	query2 := "INSERT INTO items (id, name, data, score) VALUES " +
+		"(2, 'banana', NULL, NULL), (10, 'cherry', X'FF', 2.5), " +
+		"(3, NULL, X'00', 1e20), (1, 'apple', X'CAFE', 0.1)"
+
+	conn := mysqltest.SetupDatabase(t,
+		mysqltest.RootUserCredentials(rootUser, rootPassword),
+		mysqltest.ModifyConfig(func(c *mysql.Config) {
+			c.Net = "tcp"
+			c.Addr = net.JoinHostPort("127.0.0.1", mysqlPort)
+		}),
+		mysqltest.Queries(query1, query2),
+	)
+
+	// Exercise & Verify
+	t.Run("without args", func(t *testing.T) {
+		conn.AssertQueryGolden(t, "SELECT id, name, data, score FROM items", "testdata/items.golden")
+	})
+	t.Run("with args", func(t *testing.T) {
+		conn.AssertQueryGolden(t, "SELECT id, name, data, score FROM items WHERE id <= ?", "testdata/items.golden", 10)
+	})
+}
+
 func ExampleModifyConfig() {
 	mysqltest.ModifyConfig(func(c *mysql.Config) {
 		c.Net = "tcp"